@@ -0,0 +1,57 @@
+package play
+
+// eventBuffer is the size of the channel returned by Events. Events are
+// dropped rather than blocking the caller if nothing is receiving from
+// it, so this only needs to absorb bursts between receives.
+const eventBuffer = 64
+
+// Event is emitted by App as a side effect of its public methods, so that
+// external tooling (metrics exporters, UI overlays, ...) can observe
+// activity without wrapping a Logger. KeyVals holds the event's payload
+// key/value pairs, the same ones appended after "event", <type> in the
+// Logger.Log call for this event.
+type Event struct {
+	Type    string
+	KeyVals []interface{}
+}
+
+// Logger is a minimal key/value logging interface, following
+// the go-kit style loggers common in the ecosystem.
+type Logger interface {
+	Log(keyvals ...interface{}) error
+}
+
+// SetLogger installs l as the app's logger. Every event App emits
+// (sound_added, play_start, play_error, serve_request) is logged through
+// it as "event", <type>, <keyvals...>.
+func (app *App) SetLogger(l Logger) {
+	app.loggerMu.Lock()
+	app.logger = l
+	app.loggerMu.Unlock()
+}
+
+// Events returns a channel that receives an Event for everything App
+// logs. It lets external tooling subscribe to app activity without
+// implementing Logger. The channel is shared and never closed.
+func (app *App) Events() <-chan Event {
+	return app.events
+}
+
+// emit logs keyvals through the configured Logger, if any, and publishes
+// them on the Events channel if its buffer has room; otherwise the event
+// is dropped.
+func (app *App) emit(eventType string, keyvals ...interface{}) {
+	app.loggerMu.RLock()
+	logger := app.logger
+	app.loggerMu.RUnlock()
+
+	if logger != nil {
+		all := append([]interface{}{"event", eventType}, keyvals...)
+		_ = logger.Log(all...)
+	}
+
+	select {
+	case app.events <- Event{Type: eventType, KeyVals: keyvals}:
+	default:
+	}
+}
@@ -0,0 +1,126 @@
+package play
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// soundInfo describes a registered synthdef for the /sounds endpoint.
+type soundInfo struct {
+	Name     string             `json:"name"`
+	Controls map[string]float32 `json:"controls"`
+}
+
+// playRequest is the JSON body accepted by POST /play/{name}.
+type playRequest struct {
+	Controls map[string]float32 `json:"controls"`
+}
+
+// playResponse is returned by POST /play/{name}.
+type playResponse struct {
+	ID int32 `json:"id"`
+}
+
+// Serve starts an HTTP server on addr that exposes the app's sounds as a
+// remotely controllable service:
+//
+//     GET  /sounds      list registered synthdefs and their controls
+//     POST /play/{name} play a sound, returning the resulting node ID
+//     POST /free/{id}   free a running node
+//
+// It reuses the same synthdef registry and node bookkeeping as the
+// command line and REPL modes, so a single App can be driven from any
+// combination of them.
+func (app *App) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sounds", app.logRequest(app.handleSounds))
+	mux.HandleFunc("/play/", app.logRequest(app.handlePlay))
+	mux.HandleFunc("/free/", app.logRequest(app.handleFree))
+	return http.ListenAndServe(addr, mux)
+}
+
+// logRequest wraps h so that every request it serves emits a
+// serve_request event before the handler runs.
+func (app *App) logRequest(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		app.emit("serve_request", "method", r.Method, "path", r.URL.Path)
+		h(w, r)
+	}
+}
+
+func (app *App) handleSounds(w http.ResponseWriter, r *http.Request) {
+	app.mu.RLock()
+	infos := make([]soundInfo, 0, len(app.m))
+	for name, def := range app.m {
+		ctls := map[string]float32{}
+		for _, pn := range def.ParamNames {
+			ctls[pn.Name] = def.InitialParamValues[pn.Index]
+		}
+		infos = append(infos, soundInfo{Name: name, Controls: ctls})
+	}
+	app.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (app *App) handlePlay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/play/")
+	if name == "" {
+		http.Error(w, "missing sound name", http.StatusBadRequest)
+		return
+	}
+
+	var req playRequest
+	if r.Body != nil {
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, errors.Wrap(err, "decoding request body").Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	params := make([]string, 0, len(req.Controls))
+	for k, v := range req.Controls {
+		params = append(params, k+"="+formatControl(v))
+	}
+
+	id, err := app.Play(name, params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, playResponse{ID: id})
+}
+
+func (app *App) handleFree(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/free/")
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid node id", http.StatusBadRequest)
+		return
+	}
+	if err := app.Stop(int32(id)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,57 @@
+package play
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scgolang/sc"
+)
+
+func TestHandlePlayMethodNotAllowed(t *testing.T) {
+	app := &App{}
+	w := httptest.NewRecorder()
+	app.handlePlay(w, httptest.NewRequest(http.MethodGet, "/play/bass", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandlePlayMissingName(t *testing.T) {
+	app := &App{}
+	w := httptest.NewRecorder()
+	app.handlePlay(w, httptest.NewRequest(http.MethodPost, "/play/", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleFreeInvalidID(t *testing.T) {
+	app := &App{}
+	w := httptest.NewRecorder()
+	app.handleFree(w, httptest.NewRequest(http.MethodPost, "/free/notanumber", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleFreeMethodNotAllowed(t *testing.T) {
+	app := &App{}
+	w := httptest.NewRecorder()
+	app.handleFree(w, httptest.NewRequest(http.MethodGet, "/free/1", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandleSoundsEmpty(t *testing.T) {
+	app := &App{m: map[string]*sc.Synthdef{}}
+	w := httptest.NewRecorder()
+	app.handleSounds(w, httptest.NewRequest(http.MethodGet, "/sounds", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got, want := w.Body.String(), "[]\n"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+package play
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Step is a single event in a Pattern: play Sound with Params for Dur
+// before the next step fires.
+type Step struct {
+	Sound  string
+	Params map[string]float32
+	Dur    time.Duration
+}
+
+// Pattern is a sequence of Steps to be played in a loop.
+// If Repeat is 0, the pattern repeats indefinitely.
+type Pattern struct {
+	Steps  []Step
+	Repeat int
+}
+
+// Schedule runs p in its own goroutine, advancing to the next step on a
+// monotonic time base: each tick is computed from the pattern's start
+// time rather than by sleeping from time.Now after the previous step, so
+// scheduling jitter doesn't accumulate into drift over a long-running
+// loop. The node started by a step is freed when the next step fires (or
+// when the pattern stops), so only one step of the pattern sounds at a
+// time.
+//
+// It returns a cancel func that stops the pattern and frees any node it
+// has playing. Schedule returns an error immediately if p has no steps.
+func (app *App) Schedule(p Pattern) (func(), error) {
+	if len(p.Steps) == 0 {
+		return nil, errors.New("pattern has no steps")
+	}
+
+	stop := make(chan struct{})
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() { close(stop) })
+	}
+
+	go app.runPattern(p, stop)
+
+	return cancel, nil
+}
+
+func (app *App) runPattern(p Pattern, stop <-chan struct{}) {
+	var (
+		prevID  int32
+		playing bool
+		next    = time.Now()
+		i       int
+		rounds  int
+	)
+	for {
+		select {
+		case <-stop:
+			if playing {
+				_ = app.Stop(prevID)
+			}
+			return
+		default:
+		}
+
+		step := p.Steps[i]
+		params := make([]string, 0, len(step.Params))
+		for k, v := range step.Params {
+			params = append(params, k+"="+formatControl(v))
+		}
+
+		id, err := app.Play(step.Sound, params)
+		if playing {
+			_ = app.Stop(prevID)
+		}
+		if err == nil {
+			prevID, playing = id, true
+		} else {
+			playing = false
+		}
+
+		next = next.Add(step.Dur)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-stop:
+			timer.Stop()
+			if playing {
+				_ = app.Stop(prevID)
+			}
+			return
+		case <-timer.C:
+		}
+
+		i++
+		if i == len(p.Steps) {
+			i = 0
+			rounds++
+			if p.Repeat > 0 && rounds >= p.Repeat {
+				if playing {
+					_ = app.Stop(prevID)
+				}
+				return
+			}
+		}
+	}
+}
+
+// ParsePattern parses a Pattern from r. Each non-blank line describes one
+// step as:
+//
+//     <sound> <duration> [key=value ...]
+//
+// e.g. "bass 250ms freq=55 amp=0.4". Duration is parsed with
+// time.ParseDuration.
+func ParsePattern(r io.Reader) (Pattern, error) {
+	var p Pattern
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return Pattern{}, errors.Errorf("could not parse step from %q", line)
+		}
+
+		dur, err := time.ParseDuration(fields[1])
+		if err != nil {
+			return Pattern{}, errors.Wrap(err, "parsing step duration")
+		}
+
+		params, err := parseControls(fields[2:])
+		if err != nil {
+			return Pattern{}, err
+		}
+
+		p.Steps = append(p.Steps, Step{
+			Sound:  fields[0],
+			Params: params,
+			Dur:    dur,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return Pattern{}, err
+	}
+	return p, nil
+}
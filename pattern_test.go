@@ -0,0 +1,76 @@
+package play
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatControl(t *testing.T) {
+	cases := []struct {
+		in   float32
+		want string
+	}{
+		{440, "440"},
+		{0.5, "0.5"},
+		{-1, "-1"},
+	}
+	for _, c := range cases {
+		if got := formatControl(c.in); got != c.want {
+			t.Errorf("formatControl(%v) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	p, err := ParsePattern(strings.NewReader("bass 250ms freq=55 amp=0.4\nhat 125ms\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Steps) != 2 {
+		t.Fatalf("len(p.Steps) = %d, want 2", len(p.Steps))
+	}
+
+	first := p.Steps[0]
+	if first.Sound != "bass" || first.Dur != 250*time.Millisecond {
+		t.Errorf("first step = %+v", first)
+	}
+	if first.Params["freq"] != 55 || first.Params["amp"] != 0.4 {
+		t.Errorf("first step params = %+v", first.Params)
+	}
+
+	second := p.Steps[1]
+	if second.Sound != "hat" || second.Dur != 125*time.Millisecond || len(second.Params) != 0 {
+		t.Errorf("second step = %+v", second)
+	}
+}
+
+func TestParsePatternSkipsBlankLines(t *testing.T) {
+	p, err := ParsePattern(strings.NewReader("\nbass 1s\n\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Steps) != 1 {
+		t.Fatalf("len(p.Steps) = %d, want 1", len(p.Steps))
+	}
+}
+
+func TestParsePatternErrors(t *testing.T) {
+	cases := []string{
+		"bass",              // missing duration
+		"bass notaduration", // bad duration
+		"bass 1s freq",      // malformed control
+	}
+	for _, c := range cases {
+		if _, err := ParsePattern(strings.NewReader(c)); err == nil {
+			t.Errorf("ParsePattern(%q): expected error, got nil", c)
+		}
+	}
+}
+
+func TestScheduleEmptyPattern(t *testing.T) {
+	app := &App{}
+	if _, err := app.Schedule(Pattern{}); err == nil {
+		t.Error("Schedule with no steps: expected error, got nil")
+	}
+}
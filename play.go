@@ -23,19 +23,43 @@ type App struct {
 	// Options
 	list  bool
 	sound string
+	http  string
 
 	m  map[string]*sc.Synthdef
 	mu sync.RWMutex
+
+	client *sc.Client
+
+	nodes   map[int32]string
+	nodesMu sync.RWMutex
+	nextID  int32
+
+	logger   Logger
+	loggerMu sync.RWMutex
+	events   chan Event
 }
 
 // New creates a new app with some options already added:
 //     -l        Lists the synthdefs for the app.
 //     -s SOUND  Plays a sound.
-func New(fs *flag.FlagSet) *App {
-	app := &App{m: map[string]*sc.Synthdef{}}
+//     -http ADDR  Serves the sounds over HTTP at ADDR.
+// It returns an error if the default SC client cannot be created.
+func New(fs *flag.FlagSet) (*App, error) {
+	client, err := sc.DefaultClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating default SC client")
+	}
+
+	app := &App{
+		m:      map[string]*sc.Synthdef{},
+		client: client,
+		nodes:  map[int32]string{},
+		events: make(chan Event, eventBuffer),
+	}
 	fs.BoolVar(&app.list, "l", false, "list sounds")
 	fs.StringVar(&app.sound, "s", "", "play a sound")
-	return app
+	fs.StringVar(&app.http, "http", "", "serve sounds over HTTP at the given address")
+	return app, nil
 }
 
 // Add adds a synthdef to the app.
@@ -48,6 +72,8 @@ func (app *App) Add(name string, f sc.UgenFunc) error {
 	}
 	app.m[name] = sc.NewSynthdef(name, f)
 	app.mu.Unlock()
+
+	app.emit("sound_added", "name", name)
 	return nil
 }
 
@@ -60,30 +86,103 @@ func (app *App) List() {
 	app.mu.RUnlock()
 }
 
-// Play plays a sound.
-// params should be key-value pairs formatted as "key=value"
-func (app *App) Play(sound string, params []string) error {
-	app.mu.RLock()
-	def, exists := app.m[sound]
-	if !exists {
-		app.mu.RUnlock()
-		return errors.Errorf("unrecognized sound: " + sound)
-	}
-	app.mu.RUnlock()
-
+// parseControls parses a slice of "key=value" strings into a control map.
+func parseControls(params []string) (map[string]float32, error) {
 	ctls := map[string]float32{}
 	for _, param := range params {
 		a := strings.Split(param, "=")
 		if len(a) < 2 {
-			return errors.Errorf("could not parse key=value from " + param)
+			return nil, errors.Errorf("could not parse key=value from " + param)
 		}
 		fv, err := strconv.ParseFloat(a[1], 32)
 		if err != nil {
-			return errors.Wrap(err, "parsing control value")
+			return nil, errors.Wrap(err, "parsing control value")
 		}
 		ctls[a[0]] = float32(fv)
 	}
-	return errors.Wrap(scid.Play(def, ctls), "playing synthdef")
+	return ctls, nil
+}
+
+// formatControl formats a control value as it would appear in a
+// "key=value" pair.
+func formatControl(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 32)
+}
+
+// Play plays a sound and returns the ID of the node that was created.
+// params should be key-value pairs formatted as "key=value"
+func (app *App) Play(sound string, params []string) (int32, error) {
+	app.mu.RLock()
+	def, exists := app.m[sound]
+	if !exists {
+		app.mu.RUnlock()
+		err := errors.Errorf("unrecognized sound: " + sound)
+		app.emit("play_error", "sound", sound, "error", err)
+		return 0, err
+	}
+	app.mu.RUnlock()
+
+	ctls, err := parseControls(params)
+	if err != nil {
+		app.emit("play_error", "sound", sound, "error", err)
+		return 0, err
+	}
+	if err := scid.Play(def, ctls); err != nil {
+		err = errors.Wrap(err, "playing synthdef")
+		app.emit("play_error", "sound", sound, "error", err)
+		return 0, err
+	}
+
+	app.nodesMu.Lock()
+	app.nextID++
+	id := app.nextID
+	app.nodes[id] = sound
+	app.nodesMu.Unlock()
+
+	app.emit("play_start", "sound", sound, "controls", ctls, "id", id)
+	return id, nil
+}
+
+// Stop frees the node with the provided ID.
+func (app *App) Stop(id int32) error {
+	app.nodesMu.Lock()
+	defer app.nodesMu.Unlock()
+	if _, exists := app.nodes[id]; !exists {
+		return errors.Errorf("no such node: %d", id)
+	}
+	if err := app.client.NodeFree(id); err != nil {
+		return errors.Wrap(err, "freeing node")
+	}
+	delete(app.nodes, id)
+	return nil
+}
+
+// Set updates the controls of a running node.
+// params should be key-value pairs formatted as "key=value"
+func (app *App) Set(id int32, params []string) error {
+	app.nodesMu.RLock()
+	_, exists := app.nodes[id]
+	app.nodesMu.RUnlock()
+	if !exists {
+		return errors.Errorf("no such node: %d", id)
+	}
+	ctls, err := parseControls(params)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(app.client.NodeSet(id, ctls), "setting controls")
+}
+
+// StopAll frees every node currently tracked by the app.
+// It is intended to be used when shutting down, e.g. in response to an
+// interrupt signal, so that no synths are left running on the server.
+func (app *App) StopAll() {
+	app.nodesMu.Lock()
+	defer app.nodesMu.Unlock()
+	for id := range app.nodes {
+		_ = app.client.NodeFree(id)
+		delete(app.nodes, id)
+	}
 }
 
 // Run runs the app.
@@ -93,5 +192,9 @@ func (app *App) Run(args []string) error {
 		app.List()
 		return nil
 	}
-	return app.Play(app.sound, args)
+	if app.http != "" {
+		return app.Serve(app.http)
+	}
+	_, err := app.Play(app.sound, args)
+	return err
 }
@@ -0,0 +1,52 @@
+package play
+
+import (
+	"testing"
+
+	"github.com/scgolang/sc"
+)
+
+func TestParseControls(t *testing.T) {
+	ctls, err := parseControls([]string{"freq=440", "amp=0.5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ctls["freq"] != 440 {
+		t.Errorf("ctls[freq] = %v, want 440", ctls["freq"])
+	}
+	if ctls["amp"] != 0.5 {
+		t.Errorf("ctls[amp] = %v, want 0.5", ctls["amp"])
+	}
+}
+
+func TestParseControlsErrors(t *testing.T) {
+	for _, param := range []string{"freq", "freq=notanumber"} {
+		if _, err := parseControls([]string{param}); err == nil {
+			t.Errorf("parseControls(%q): expected error, got nil", param)
+		}
+	}
+}
+
+func TestAddDuplicate(t *testing.T) {
+	app := &App{m: map[string]*sc.Synthdef{"bass": nil}}
+	if err := app.Add("bass", nil); err != ErrDupl {
+		t.Errorf("Add returned %v, want ErrDupl", err)
+	}
+}
+
+func TestPlayUnrecognizedSound(t *testing.T) {
+	app := &App{m: map[string]*sc.Synthdef{}, events: make(chan Event, eventBuffer)}
+	if _, err := app.Play("nope", nil); err == nil {
+		t.Error("Play with unrecognized sound: expected error, got nil")
+	}
+}
+
+func TestPlayBadControl(t *testing.T) {
+	app := &App{
+		m:      map[string]*sc.Synthdef{"bass": nil},
+		events: make(chan Event, eventBuffer),
+	}
+	if _, err := app.Play("bass", []string{"freq"}); err == nil {
+		t.Error("Play with malformed control: expected error, got nil")
+	}
+}
@@ -0,0 +1,161 @@
+package play
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// REPL runs an interactive prompt that reads commands from in and writes
+// output and prompts to out. It keeps a single SC server connection open
+// for the lifetime of the session, which makes it convenient to audition
+// and tweak synthdefs without restarting the process between plays.
+//
+// Supported commands:
+//     list                       list the registered sounds
+//     play <sound> [k=v ...]     play a sound, printing the node ID
+//     stop <nodeID>              free a running node
+//     set <nodeID> k=v [...]     update the controls of a running node
+//     help                       print this command summary
+//
+// REPL returns when in is exhausted (EOF) or an interrupt signal (e.g.
+// Ctrl-C) is received. In both cases it frees any nodes still running,
+// and it waits for its read-dispatch goroutine to exit before returning,
+// so no dispatch can run against the app after the caller regains
+// control.
+func (app *App) REPL(in io.Reader, out io.Writer) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- app.runREPL(in, out, stop)
+	}()
+
+	select {
+	case err := <-done:
+		app.StopAll()
+		return err
+	case <-sigCh:
+		fmt.Fprintln(out, "\ninterrupted, stopping all sounds")
+		close(stop)
+		if closer, ok := in.(io.Closer); ok {
+			_ = closer.Close()
+		}
+		app.StopAll()
+		<-done
+		return nil
+	}
+}
+
+// runREPL drives the read-dispatch loop. It is split out from REPL so that
+// REPL can select between it finishing and an interrupt signal arriving.
+// It exits when scanner.Scan returns false (EOF, or in was closed by REPL
+// in response to an interrupt) or stop is closed.
+func (app *App) runREPL(in io.Reader, out io.Writer, stop <-chan struct{}) error {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(out, "> ")
+	for scanner.Scan() {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			if err := app.dispatch(strings.Fields(line), out); err != nil {
+				fmt.Fprintln(out, "error:", err)
+			}
+		}
+		fmt.Fprint(out, "> ")
+	}
+	return scanner.Err()
+}
+
+// dispatch runs a single tokenized REPL command.
+func (app *App) dispatch(tokens []string, out io.Writer) error {
+	cmd, args := tokens[0], tokens[1:]
+	switch cmd {
+	case "list":
+		return app.replList(out)
+	case "play":
+		return app.replPlay(args, out)
+	case "stop":
+		return app.replStop(args)
+	case "set":
+		return app.replSet(args)
+	case "help":
+		return app.replHelp(out)
+	default:
+		return errors.Errorf("unrecognized command: %s", cmd)
+	}
+}
+
+func (app *App) replList(out io.Writer) error {
+	app.mu.RLock()
+	names := make([]string, 0, len(app.m))
+	for name := range app.m {
+		names = append(names, name)
+	}
+	app.mu.RUnlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(out, name)
+	}
+	return nil
+}
+
+func (app *App) replPlay(args []string, out io.Writer) error {
+	if len(args) < 1 {
+		return errors.New("usage: play <sound> [key=value ...]")
+	}
+	id, err := app.Play(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "started node %d\n", id)
+	return nil
+}
+
+func (app *App) replStop(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: stop <nodeID>")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil {
+		return errors.Wrap(err, "parsing node ID")
+	}
+	return app.Stop(int32(id))
+}
+
+func (app *App) replSet(args []string) error {
+	if len(args) < 2 {
+		return errors.New("usage: set <nodeID> key=value [...]")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil {
+		return errors.Wrap(err, "parsing node ID")
+	}
+	return app.Set(int32(id), args[1:])
+}
+
+func (app *App) replHelp(out io.Writer) error {
+	fmt.Fprintln(out, "commands:")
+	fmt.Fprintln(out, "  list                       list the registered sounds")
+	fmt.Fprintln(out, "  play <sound> [k=v ...]     play a sound, printing the node ID")
+	fmt.Fprintln(out, "  stop <nodeID>              free a running node")
+	fmt.Fprintln(out, "  set <nodeID> k=v [...]     update the controls of a running node")
+	fmt.Fprintln(out, "  help                       print this command summary")
+	return nil
+}
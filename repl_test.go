@@ -0,0 +1,66 @@
+package play
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/scgolang/sc"
+)
+
+func TestDispatchUnrecognizedCommand(t *testing.T) {
+	app := &App{}
+	var buf bytes.Buffer
+	if err := app.dispatch([]string{"bogus"}, &buf); err == nil {
+		t.Error("dispatch(bogus): expected error, got nil")
+	}
+}
+
+func TestDispatchHelp(t *testing.T) {
+	app := &App{}
+	var buf bytes.Buffer
+	if err := app.dispatch([]string{"help"}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "list") {
+		t.Errorf("help output missing command summary: %q", buf.String())
+	}
+}
+
+func TestDispatchListSorted(t *testing.T) {
+	app := &App{m: map[string]*sc.Synthdef{"zz": nil, "aa": nil}}
+	var buf bytes.Buffer
+	if err := app.dispatch([]string{"list"}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "aa\nzz\n"; buf.String() != want {
+		t.Errorf("list output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestDispatchPlayUsage(t *testing.T) {
+	app := &App{}
+	var buf bytes.Buffer
+	if err := app.dispatch([]string{"play"}, &buf); err == nil {
+		t.Error("play with no args: expected usage error, got nil")
+	}
+}
+
+func TestDispatchStopUsage(t *testing.T) {
+	app := &App{}
+	var buf bytes.Buffer
+	if err := app.dispatch([]string{"stop"}, &buf); err == nil {
+		t.Error("stop with no args: expected usage error, got nil")
+	}
+	if err := app.dispatch([]string{"stop", "notanumber"}, &buf); err == nil {
+		t.Error("stop with non-numeric node ID: expected error, got nil")
+	}
+}
+
+func TestDispatchSetUsage(t *testing.T) {
+	app := &App{}
+	var buf bytes.Buffer
+	if err := app.dispatch([]string{"set", "1"}, &buf); err == nil {
+		t.Error("set with no key=value pairs: expected usage error, got nil")
+	}
+}